@@ -0,0 +1,97 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * whoisd centralizes whois/RDAP lookups behind a gRPC service so callers
+ * don't each need to open their own port-43 connection
+ */
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"log"
+	"net"
+	"os"
+
+	"github.com/ducksify/whois"
+	"github.com/ducksify/whois/whoisgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func main() {
+	addr := flag.String("addr", ":8443", "address to serve WhoisService on")
+	clientCA := flag.String("client-ca", "", "PEM file of CA certificates trusted to authenticate clients over mutual TLS")
+	serverCert := flag.String("cert", "", "PEM file of the server's TLS certificate")
+	serverKey := flag.String("key", "", "PEM file of the server's TLS private key")
+	maxConcurrentPerHost := flag.Int("max-concurrent-per-host", 2, "default per-host concurrency limit for batch queries")
+	rateLimit := flag.Float64("rate-limit", 0, "default per-host queries-per-second limit for batch queries")
+	flag.Parse()
+
+	client := whois.NewRDAPClient()
+	client.MaxConcurrentPerHost = *maxConcurrentPerHost
+	client.RateLimit = *rateLimit
+
+	var opts []grpc.ServerOption
+	if *serverCert != "" {
+		creds, err := serverCredentials(*serverCert, *serverKey, *clientCA)
+		if err != nil {
+			log.Fatalf("whoisd: failed to load TLS credentials: %v", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	server := whoisgrpc.NewServer(client, opts...)
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("whoisd: failed to listen on %s: %v", *addr, err)
+	}
+
+	log.Printf("whoisd: serving WhoisService on %s", *addr)
+	if err := server.Serve(listener); err != nil {
+		log.Fatalf("whoisd: %v", err)
+	}
+}
+
+// serverCredentials builds TLS transport credentials for the gRPC server,
+// requiring and verifying client certificates against clientCAFile when set
+func serverCredentials(certFile, keyFile, clientCAFile string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			log.Fatalf("whoisd: no valid certificates found in %s", clientCAFile)
+		}
+
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return credentials.NewTLS(config), nil
+}
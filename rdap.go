@@ -23,9 +23,16 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
+// defaultMaxRDAPRedirects bounds how many rel=related / HTTP 3xx hops
+// QueryRDAP will follow before giving up
+const defaultMaxRDAPRedirects = 5
+
 // RDAPResponse represents the standard RDAP response structure
 type RDAPResponse struct {
 	RdapConformance []string               `json:"rdapConformance"`
@@ -59,6 +66,12 @@ type RDAPResponse struct {
 	EmailAddresses  []RDAPEmailAddress     `json:"emailAddresses,omitempty"`
 	RawWhois        string                 `json:"rawWhois,omitempty"`
 	WhoisParsed     map[string]interface{} `json:"whoisParsed,omitempty"`
+
+	// cacheControlTTL is the max-age parsed from the RDAP HTTP response's
+	// Cache-Control header, if any. It isn't part of the RDAP wire format;
+	// it exists so QueryBatch can honor the server's own cache hint instead
+	// of guessing one from unrelated RDAP events.
+	cacheControlTTL time.Duration
 }
 
 // RDAPNotice represents RDAP notices
@@ -83,6 +96,25 @@ type RDAPEntity struct {
 	Addresses       []RDAPAddress      `json:"addresses,omitempty"`
 	PhoneNumbers    []RDAPPhoneNumber  `json:"phoneNumbers,omitempty"`
 	EmailAddresses  []RDAPEmailAddress `json:"emailAddresses,omitempty"`
+
+	// The following fields are populated from VCardArray by parseVCard and
+	// are not part of the RDAP wire format themselves
+	FullName         string    `json:"fullName,omitempty"`
+	Name             *RDAPName `json:"name,omitempty"`
+	OrganizationName string    `json:"organizationName,omitempty"`
+	Kind             string    `json:"kind,omitempty"`
+	Lang             string    `json:"lang,omitempty"`
+	JobTitle         string    `json:"jobTitle,omitempty"`
+}
+
+// RDAPName represents the structured "n" jCard property: family name,
+// given name, additional names, honorific prefixes and suffixes
+type RDAPName struct {
+	Family     string `json:"family,omitempty"`
+	Given      string `json:"given,omitempty"`
+	Additional string `json:"additional,omitempty"`
+	Prefixes   string `json:"prefixes,omitempty"`
+	Suffixes   string `json:"suffixes,omitempty"`
 }
 
 // RDAPEvent represents RDAP events
@@ -195,6 +227,37 @@ type RDAPEmailAddress struct {
 type RDAPClient struct {
 	*Client
 	httpClient *http.Client
+
+	// Bootstrap resolves RDAP base URLs from IANA's bootstrap registry
+	Bootstrap *RDAPBootstrap
+	// MaxRedirects bounds how many rel=related / HTTP 3xx hops to follow
+	MaxRedirects int
+
+	// Type forces the RDAP object class (domain/ip/autnum/nameserver/entity)
+	// for QueryRDAP instead of auto-detecting it from the query string, for
+	// cases like a bare handle that looks like an ASN
+	Type string
+	// Server overrides bootstrap entirely with an explicit RDAP base URL,
+	// used for object classes bootstrap has no registry for (entity).
+	// QueryHelp takes its server as an argument and does not read this field
+	Server string
+
+	// MaxConcurrentPerHost is the default per-host concurrency limit used
+	// by QueryBatch when a call's BatchOptions doesn't set one
+	MaxConcurrentPerHost int
+	// GlobalConcurrency is the default total concurrency limit used by
+	// QueryBatch when a call's BatchOptions doesn't set one
+	GlobalConcurrency int
+	// RateLimit is the default per-host queries-per-second limit used by
+	// QueryBatch when a call's BatchOptions doesn't set one
+	RateLimit float64
+	// RetryOn429WithBackoff is the default retry behavior used by
+	// QueryBatch when a call's BatchOptions doesn't set one
+	RetryOn429WithBackoff bool
+
+	mu           sync.Mutex
+	hostLimiters map[string]*hostLimiter
+	batchCache   *responseCache
 }
 
 // NewRDAPClient creates a new RDAP client
@@ -204,18 +267,37 @@ func NewRDAPClient() *RDAPClient {
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
+		Bootstrap:    NewRDAPBootstrap(),
+		MaxRedirects: defaultMaxRDAPRedirects,
 	}
 }
 
-// QueryRDAP performs an RDAP query and returns structured RDAP response
+// QueryRDAP performs an RDAP query and returns structured RDAP response.
+// It first tries to resolve a real RDAP endpoint via the IANA bootstrap
+// registry and query it directly over HTTPS, falling back to synthesizing
+// a response from classic WHOIS text when no RDAP endpoint can be found
+// or the RDAP server itself errors out.
 func (rc *RDAPClient) QueryRDAP(query string) (*RDAPResponse, error) {
-	// First get the raw WHOIS data
-	whoisData, err := rc.Whois(query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query WHOIS: %w", err)
+	return rc.QueryRDAPAs(query, rc.Type, rc.Server)
+}
+
+// QueryRDAPAs performs an RDAP query exactly like QueryRDAP, but overrides
+// the object class and/or RDAP server for this call only, instead of
+// rc.Type/rc.Server (which are still read as fallbacks when objectType or
+// server is empty). It doesn't write any field on rc, so it's safe to call
+// concurrently with other queries sharing the same client, e.g. a server
+// handling per-request overrides on behalf of many callers.
+func (rc *RDAPClient) QueryRDAPAs(query, objectType, server string) (*RDAPResponse, error) {
+	response, err := rc.queryRDAPServer(query, objectType, server)
+	if err == nil {
+		return response, nil
+	}
+
+	whoisData, whoisErr := rc.Whois(query)
+	if whoisErr != nil {
+		return nil, fmt.Errorf("failed to query WHOIS: %w", whoisErr)
 	}
 
-	// Convert WHOIS data to RDAP format
 	rdapResponse, err := rc.convertWhoisToRDAP(query, whoisData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert WHOIS to RDAP: %w", err)
@@ -224,6 +306,169 @@ func (rc *RDAPClient) QueryRDAP(query string) (*RDAPResponse, error) {
 	return rdapResponse, nil
 }
 
+// queryRDAPServer resolves the authoritative RDAP base URL for query via
+// bootstrap and issues the HTTPS request directly, decoding the JSON body
+// into an RDAPResponse and following rel=related links and HTTP redirects
+func (rc *RDAPClient) queryRDAPServer(query, objectType, server string) (*RDAPResponse, error) {
+	base, objectClass, err := rc.resolveRDAPEndpoint(query, objectType, server)
+	if err != nil {
+		return nil, err
+	}
+
+	return rc.followRDAP(base + "/" + objectClass + "/" + query)
+}
+
+// followRDAP fetches url and, while the response (or an HTTP redirect)
+// points elsewhere via rel=related links or a 3xx status, keeps following
+// up to rc.MaxRedirects hops. Each URL is only ever fetched once, so a
+// referral cycle terminates instead of looping until the hop limit.
+func (rc *RDAPClient) followRDAP(url string) (*RDAPResponse, error) {
+	var response *RDAPResponse
+	var err error
+
+	visited := make(map[string]bool)
+
+	for hop := 0; ; hop++ {
+		if hop > rc.maxRedirects() {
+			return nil, fmt.Errorf("too many RDAP redirects for %s", url)
+		}
+		if visited[url] {
+			return nil, fmt.Errorf("RDAP referral cycle detected at %s", url)
+		}
+		visited[url] = true
+
+		response, url, err = rc.fetchRDAP(url)
+		if err != nil {
+			return nil, err
+		}
+		if url == "" {
+			break
+		}
+	}
+
+	return response, nil
+}
+
+// resolveRDAPEndpoint picks the bootstrap base URL and RDAP object class
+// (domain/ip/autnum/nameserver/entity) for query, honoring objectType and
+// server as overrides for this call (each falling back to rc.Type/rc.Server,
+// then auto-detection/bootstrap, when left empty)
+func (rc *RDAPClient) resolveRDAPEndpoint(query, objectType, server string) (base, objectClass string, err error) {
+	objectClass = objectType
+	if objectClass == "" {
+		objectClass = rc.Type
+	}
+	if objectClass == "" {
+		switch {
+		case isDomain(query):
+			objectClass = "domain"
+		case isIP(query):
+			objectClass = "ip"
+		case isASN(query):
+			objectClass = "autnum"
+		default:
+			return "", "", fmt.Errorf("unable to determine RDAP object class for %q", query)
+		}
+	}
+
+	if server != "" {
+		return server, objectClass, nil
+	}
+	if rc.Server != "" {
+		return rc.Server, objectClass, nil
+	}
+
+	base, err = rc.baseFor(objectClass, query)
+	return base, objectClass, err
+}
+
+// fetchRDAP issues a single HTTPS GET against url and decodes the RDAP JSON
+// body. If the response or its links point elsewhere via rel=related, the
+// next URL to follow is returned so the caller can continue hopping
+func (rc *RDAPClient) fetchRDAP(url string) (response *RDAPResponse, next string, err error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", "application/rdap+json")
+
+	resp, err := rc.httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("RDAP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		location := resp.Header.Get("Location")
+		if location == "" {
+			return nil, "", fmt.Errorf("RDAP redirect from %s had no Location header", url)
+		}
+		return nil, location, nil
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", fmt.Errorf("RDAP server returned 404 for %s", url)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("RDAP server returned unexpected status %s for %s", resp.Status, url)
+	}
+
+	response = &RDAPResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, "", fmt.Errorf("failed to decode RDAP response: %w", err)
+	}
+	response.cacheControlTTL = cacheControlMaxAge(resp.Header)
+
+	if related := relatedRDAPLink(response.Links); related != "" {
+		return response, related, nil
+	}
+
+	return response, "", nil
+}
+
+// relatedRDAPLink returns the href of the first rel=related link that
+// itself points at another RDAP JSON endpoint. A rel=related link can just
+// as easily point at a registrar's website or a help page, and blindly
+// following those would discard an already-valid RDAP response when the
+// referral target fails to decode as JSON, so only links explicitly typed
+// application/rdap+json are treated as referrals to keep fetching.
+func relatedRDAPLink(links []RDAPLink) string {
+	for _, link := range links {
+		if link.Rel == "related" && strings.Contains(strings.ToLower(link.Type), "rdap+json") {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+// cacheControlMaxAge extracts the max-age directive from an HTTP
+// Cache-Control header, returning 0 when absent or unparsable
+func cacheControlMaxAge(header http.Header) time.Duration {
+	cc := header.Get("Cache-Control")
+	if cc == "" {
+		return 0
+	}
+
+	for _, directive := range strings.Split(cc, ",") {
+		directive = strings.TrimSpace(directive)
+		if !strings.HasPrefix(directive, "max-age=") {
+			continue
+		}
+		if secs, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+	}
+
+	return 0
+}
+
+func (rc *RDAPClient) maxRedirects() int {
+	if rc.MaxRedirects > 0 {
+		return rc.MaxRedirects
+	}
+	return defaultMaxRDAPRedirects
+}
+
 // QueryRDAP is a convenience function using the default client
 func QueryRDAP(query string) (*RDAPResponse, error) {
 	client := NewRDAPClient()
@@ -263,6 +508,8 @@ func (rc *RDAPClient) convertWhoisToRDAP(query, whoisData string) (*RDAPResponse
 		Description: []string{"This response has been truncated due to authorization."},
 	})
 
+	populateVCards(response.Entities)
+
 	return response, nil
 }
 
@@ -439,4 +686,3 @@ func getIPVersion(query string) string {
 func (r *RDAPResponse) ToJSON() ([]byte, error) {
 	return json.Marshal(r)
 }
-
@@ -0,0 +1,372 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Concurrent batch RDAP/WHOIS queries for whois package
+ */
+
+package whois
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultBatchCacheTTL is used for cached responses whose TTL can't be
+// derived from HTTP cache headers
+const defaultBatchCacheTTL = 5 * time.Minute
+
+// maxDerivedCacheTTL caps how long a response can be cached even when the
+// server's own Cache-Control max-age asks for longer, so a stale
+// misconfigured registry can't pin a batch's in-process cache forever
+const maxDerivedCacheTTL = time.Hour
+
+// BatchOptions configures a QueryBatch call. A zero value for any field
+// other than CacheTTL falls back to the same-named field on RDAPClient,
+// which in turn falls back to a sane default; a zero CacheTTL falls back
+// to defaultBatchCacheTTL
+type BatchOptions struct {
+	// MaxConcurrentPerHost bounds how many requests run at once against a
+	// single resolved RDAP/WHOIS server
+	MaxConcurrentPerHost int
+	// GlobalConcurrency bounds how many requests run at once across all hosts
+	GlobalConcurrency int
+	// RateLimit is the maximum queries per second issued to any one host
+	RateLimit float64
+	// RetryOn429WithBackoff retries a request once, after a one-second
+	// pause, when a host returns HTTP 429
+	RetryOn429WithBackoff bool
+	// CacheTTL overrides the default TTL used when a response carries no
+	// usable Cache-Control max-age
+	CacheTTL time.Duration
+}
+
+// BatchResult is one query's outcome from QueryBatch, delivered as soon as
+// it completes
+type BatchResult struct {
+	Query    string
+	Response *RDAPResponse
+	Err      error
+	Elapsed  time.Duration
+	Server   string
+}
+
+// hostLimiter bounds concurrency and request rate for a single resolved
+// RDAP/WHOIS host
+type hostLimiter struct {
+	sem    chan struct{}
+	bucket *tokenBucket
+}
+
+// tokenBucket is a minimal token-bucket rate limiter: tokens refill
+// continuously at ratePerSec up to capacity, and wait blocks until one
+// is available or the context is done
+type tokenBucket struct {
+	mu         sync.Mutex
+	tokens     float64
+	capacity   float64
+	ratePerSec float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(ratePerSec float64) *tokenBucket {
+	if ratePerSec <= 0 {
+		return nil
+	}
+	capacity := ratePerSec
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &tokenBucket{
+		tokens:     capacity,
+		capacity:   capacity,
+		ratePerSec: ratePerSec,
+		lastRefill: time.Now(),
+	}
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		elapsed := now.Sub(b.lastRefill).Seconds()
+		b.tokens = minFloat(b.capacity, b.tokens+elapsed*b.ratePerSec)
+		b.lastRefill = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+
+		wait := time.Duration((1 - b.tokens) / b.ratePerSec * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// responseCache is an in-memory cache of RDAP responses keyed on
+// (server, objectClass, key), so repeat queries within a batch hit the
+// cache instead of re-querying the same registry
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	response  *RDAPResponse
+	expiresAt time.Time
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: make(map[string]cachedResponse)}
+}
+
+func cacheKey(server, objectClass, key string) string {
+	return server + "|" + objectClass + "|" + strings.ToLower(key)
+}
+
+func (c *responseCache) get(key string) (*RDAPResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.response, true
+}
+
+func (c *responseCache) set(key string, response *RDAPResponse, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cachedResponse{response: response, expiresAt: time.Now().Add(ttl)}
+}
+
+// responseTTL derives a cache TTL from the RDAP server's own Cache-Control
+// max-age, capped at maxDerivedCacheTTL, falling back to def when the
+// server gave no cache header. RDAP's "expiration" event is the domain's
+// registry expiry date, not a cache hint (it's often years out), so it is
+// deliberately not used here.
+func responseTTL(response *RDAPResponse, def time.Duration) time.Duration {
+	if response.cacheControlTTL <= 0 {
+		return def
+	}
+	if response.cacheControlTTL > maxDerivedCacheTTL {
+		return maxDerivedCacheTTL
+	}
+	return response.cacheControlTTL
+}
+
+// QueryBatch fans out queries concurrently, grouping them by resolved
+// RDAP/WHOIS server and applying a per-host concurrency and rate limit so
+// strict registries like ARIN don't ban the caller. Results stream on the
+// returned channel as they complete; the channel is closed once every
+// query has been answered or ctx is done.
+func (rc *RDAPClient) QueryBatch(ctx context.Context, queries []string, opts BatchOptions) <-chan BatchResult {
+	results := make(chan BatchResult, len(queries))
+
+	globalConcurrency := opts.GlobalConcurrency
+	if globalConcurrency <= 0 {
+		globalConcurrency = rc.GlobalConcurrency
+	}
+	if globalConcurrency <= 0 {
+		globalConcurrency = 10
+	}
+
+	perHost := opts.MaxConcurrentPerHost
+	if perHost <= 0 {
+		perHost = rc.MaxConcurrentPerHost
+	}
+	if perHost <= 0 {
+		perHost = 2
+	}
+
+	rate := opts.RateLimit
+	if rate <= 0 {
+		rate = rc.RateLimit
+	}
+
+	cacheTTL := opts.CacheTTL
+	if cacheTTL <= 0 {
+		cacheTTL = defaultBatchCacheTTL
+	}
+
+	rc.mu.Lock()
+	if rc.batchCache == nil {
+		rc.batchCache = newResponseCache()
+	}
+	rc.mu.Unlock()
+
+	global := make(chan struct{}, globalConcurrency)
+
+	rc.mu.Lock()
+	if rc.hostLimiters == nil {
+		rc.hostLimiters = make(map[string]*hostLimiter)
+	}
+	rc.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, query := range queries {
+		query := query
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			select {
+			case global <- struct{}{}:
+				defer func() { <-global }()
+			case <-ctx.Done():
+				results <- BatchResult{Query: query, Err: ctx.Err()}
+				return
+			}
+
+			rc.runBatchQuery(ctx, query, perHost, rate, cacheTTL, opts.RetryOn429WithBackoff || rc.RetryOn429WithBackoff, results)
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+// runBatchQuery resolves query's server, applies that host's limiter, and
+// executes the query, honoring the cache and optional 429 backoff retry
+func (rc *RDAPClient) runBatchQuery(ctx context.Context, query string, perHost int, rate float64, cacheTTL time.Duration, retry429 bool, results chan<- BatchResult) {
+	start := time.Now()
+
+	base, objectClass, resolveErr := rc.resolveRDAPEndpoint(query, "", "")
+	server := base
+	if resolveErr != nil {
+		server = whoisHostKey(query)
+	}
+
+	limiter := rc.limiterFor(server, perHost, rate)
+
+	if err := limiter.bucket.wait(ctx); err != nil {
+		results <- BatchResult{Query: query, Err: err, Elapsed: time.Since(start), Server: server}
+		return
+	}
+
+	select {
+	case limiter.sem <- struct{}{}:
+		defer func() { <-limiter.sem }()
+	case <-ctx.Done():
+		results <- BatchResult{Query: query, Err: ctx.Err(), Elapsed: time.Since(start), Server: server}
+		return
+	}
+
+	key := cacheKey(server, objectClass, query)
+	if cached, ok := rc.batchCache.get(key); ok {
+		results <- BatchResult{Query: query, Response: cached, Elapsed: time.Since(start), Server: server}
+		return
+	}
+
+	response, err := rc.queryWithRetry(query, retry429)
+	if err == nil {
+		rc.batchCache.set(key, response, responseTTL(response, cacheTTL))
+	}
+
+	results <- BatchResult{Query: query, Response: response, Err: err, Elapsed: time.Since(start), Server: server}
+}
+
+// queryWithRetry queries the RDAP server the way QueryRDAP does, retrying
+// once with a short backoff if the RDAP failure looks like an HTTP 429 and
+// retry429 is enabled, and falls back to WHOIS like QueryRDAP if the RDAP
+// query still fails
+func (rc *RDAPClient) queryWithRetry(query string, retry429 bool) (*RDAPResponse, error) {
+	response, err := rc.queryRDAPServer(query, "", "")
+	if err != nil && retry429 && strings.Contains(err.Error(), "429") {
+		time.Sleep(time.Second)
+		response, err = rc.queryRDAPServer(query, "", "")
+	}
+	if err == nil {
+		return response, nil
+	}
+
+	whoisData, whoisErr := rc.Whois(query)
+	if whoisErr != nil {
+		return nil, fmt.Errorf("failed to query WHOIS: %w", whoisErr)
+	}
+	return rc.convertWhoisToRDAP(query, whoisData)
+}
+
+// whoisHostKey returns a best-effort per-host bucket key for a query that
+// fell back to WHOIS, so QueryBatch's per-host concurrency/rate limiting
+// doesn't collapse every WHOIS target onto one shared bucket. It can't see
+// which server the embedded Client actually dials internally, so it
+// buckets on what's known to determine that in practice: the TLD for
+// domains, and the object class for IPs/ASNs, each of which resolves to
+// one of a handful of registry WHOIS servers.
+func whoisHostKey(query string) string {
+	switch {
+	case isDomain(query):
+		if i := strings.LastIndex(query, "."); i != -1 {
+			return "whois:" + strings.ToLower(query[i+1:])
+		}
+		return "whois:domain"
+	case isIP(query):
+		return "whois:ip"
+	case isASN(query):
+		return "whois:autnum"
+	default:
+		return "whois:entity"
+	}
+}
+
+// limiterFor returns (creating if necessary) the per-host limiter for
+// server. The perHost/rate a host's limiter is built with are whichever
+// call first creates it; later QueryBatch calls against the same client
+// and host reuse that limiter as-is, even if their BatchOptions ask for
+// different values, since a semaphore's capacity and a token bucket's
+// rate can't be resized once live goroutines may be holding a slot.
+func (rc *RDAPClient) limiterFor(server string, perHost int, rate float64) *hostLimiter {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	limiter, ok := rc.hostLimiters[server]
+	if !ok {
+		limiter = &hostLimiter{
+			sem:    make(chan struct{}, perHost),
+			bucket: newTokenBucket(rate),
+		}
+		rc.hostLimiters[server] = limiter
+	}
+	return limiter
+}
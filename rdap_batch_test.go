@@ -0,0 +1,106 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Concurrent batch RDAP/WHOIS query tests for whois package
+ */
+
+package whois
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucketAllowsImmediateFirstWait(t *testing.T) {
+	bucket := newTokenBucket(100)
+	ctx := context.Background()
+
+	start := time.Now()
+	assert.Nil(t, bucket.wait(ctx))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestTokenBucketNilIsUnbounded(t *testing.T) {
+	var bucket *tokenBucket
+	assert.Nil(t, bucket.wait(context.Background()))
+}
+
+func TestResponseCacheGetSet(t *testing.T) {
+	cache := newResponseCache()
+	key := cacheKey("https://rdap.example.test", "domain", "example.com")
+
+	_, ok := cache.get(key)
+	assert.False(t, ok)
+
+	response := &RDAPResponse{ObjectClassName: "domain"}
+	cache.set(key, response, time.Minute)
+
+	cached, ok := cache.get(key)
+	assert.True(t, ok)
+	assert.Equal(t, response, cached)
+}
+
+func TestResponseCacheExpires(t *testing.T) {
+	cache := newResponseCache()
+	key := cacheKey("https://rdap.example.test", "domain", "example.com")
+
+	cache.set(key, &RDAPResponse{}, -time.Minute)
+	_, ok := cache.get(key)
+	assert.False(t, ok)
+}
+
+func TestResponseTTLFallsBackToDefault(t *testing.T) {
+	response := &RDAPResponse{}
+	assert.Equal(t, 5*time.Minute, responseTTL(response, 5*time.Minute))
+}
+
+func TestResponseTTLIgnoresExpirationEvent(t *testing.T) {
+	// RDAP's "expiration" event is the domain's registry expiry date, not
+	// a cache hint, and must never be used to derive a cache TTL.
+	response := &RDAPResponse{
+		Events: []RDAPEvent{
+			{EventAction: "expiration", EventDate: time.Now().Add(24 * 365 * time.Hour).Format(time.RFC3339)},
+		},
+	}
+
+	assert.Equal(t, time.Minute, responseTTL(response, time.Minute))
+}
+
+func TestResponseTTLUsesCacheControlCappedAtMax(t *testing.T) {
+	response := &RDAPResponse{cacheControlTTL: 6 * time.Hour}
+	assert.Equal(t, maxDerivedCacheTTL, responseTTL(response, time.Minute))
+
+	response = &RDAPResponse{cacheControlTTL: 30 * time.Second}
+	assert.Equal(t, 30*time.Second, responseTTL(response, time.Minute))
+}
+
+func TestWhoisHostKeyGroupsByTLDOrObjectClass(t *testing.T) {
+	assert.Equal(t, "whois:com", whoisHostKey("example.com"))
+	assert.Equal(t, "whois:ru", whoisHostKey("egger.RU"))
+	assert.Equal(t, "whois:ip", whoisHostKey("192.0.2.1"))
+	assert.Equal(t, "whois:autnum", whoisHostKey("AS15169"))
+}
+
+func TestCacheControlMaxAge(t *testing.T) {
+	header := http.Header{}
+	header.Set("Cache-Control", "public, max-age=120")
+	assert.Equal(t, 120*time.Second, cacheControlMaxAge(header))
+
+	assert.Equal(t, time.Duration(0), cacheControlMaxAge(http.Header{}))
+}
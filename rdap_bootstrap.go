@@ -0,0 +1,405 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * IANA RDAP bootstrap registry for whois package
+ */
+
+package whois
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// bootstrapBaseURL is where IANA publishes the RDAP bootstrap files
+const bootstrapBaseURL = "https://data.iana.org/rdap/"
+
+// defaultBootstrapTTL is used when the HTTP response carries no
+// Cache-Control max-age; the bootstrap file's publication date is not
+// consulted
+const defaultBootstrapTTL = 24 * time.Hour
+
+// bootstrapKinds are the four registries IANA publishes
+var bootstrapKinds = []string{"dns", "ipv4", "ipv6", "asn"}
+
+// rdapBootstrapFile mirrors the JSON structure IANA publishes for each
+// registry: a version, a publication date and a list of services, where
+// each service is a 2-element array of [keys, urls]
+type rdapBootstrapFile struct {
+	Version     string              `json:"version"`
+	Publication string              `json:"publication"`
+	Description string              `json:"description"`
+	Services    [][]json.RawMessage `json:"services"`
+}
+
+// bootstrapEntry is one parsed [keys, urls] service pair
+type bootstrapEntry struct {
+	keys []string
+	urls []string
+}
+
+// bootstrapRegistry holds the parsed entries for one bootstrap kind plus
+// the point in time the cached copy should be considered stale
+type bootstrapRegistry struct {
+	entries   []bootstrapEntry
+	expiresAt time.Time
+}
+
+// RDAPBootstrap resolves RDAP base URLs for domains, IPs and ASNs using
+// IANA's bootstrap registry (RFC 7484), caching the registry files on disk
+type RDAPBootstrap struct {
+	// CacheDir is where bootstrap files are cached, default ~/.whois/rdap-bootstrap/
+	CacheDir string
+	// TTL is how long a cached registry is used before being refreshed, for
+	// registries loaded from disk and for fetches whose HTTP response has no
+	// Cache-Control max-age (a max-age, when present, takes precedence)
+	TTL time.Duration
+
+	httpClient *http.Client
+
+	mu         sync.Mutex
+	registries map[string]*bootstrapRegistry
+	refreshing map[string]bool
+}
+
+// NewRDAPBootstrap creates a bootstrap resolver with the default cache
+// directory and TTL
+func NewRDAPBootstrap() *RDAPBootstrap {
+	home, err := os.UserHomeDir()
+	cacheDir := ".whois/rdap-bootstrap"
+	if err == nil {
+		cacheDir = filepath.Join(home, ".whois", "rdap-bootstrap")
+	}
+
+	return &RDAPBootstrap{
+		CacheDir:   cacheDir,
+		TTL:        defaultBootstrapTTL,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		registries: make(map[string]*bootstrapRegistry),
+		refreshing: make(map[string]bool),
+	}
+}
+
+// DomainServer returns the RDAP base URL responsible for the TLD of domain
+func (b *RDAPBootstrap) DomainServer(domain string) (string, error) {
+	tld := domain
+	if i := strings.LastIndex(domain, "."); i >= 0 {
+		tld = domain[i+1:]
+	}
+	tld = strings.ToLower(tld)
+
+	registry, err := b.registry("dns")
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range registry.entries {
+		for _, key := range entry.keys {
+			if strings.ToLower(key) == tld {
+				return pickURL(entry.urls)
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no RDAP bootstrap entry for TLD %q", tld)
+}
+
+// IPServer returns the RDAP base URL responsible for the network containing ip
+func (b *RDAPBootstrap) IPServer(ip string) (string, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return "", fmt.Errorf("invalid IP address %q", ip)
+	}
+
+	kind := "ipv4"
+	if parsed.To4() == nil {
+		kind = "ipv6"
+	}
+
+	registry, err := b.registry(kind)
+	if err != nil {
+		return "", err
+	}
+
+	var bestURLs []string
+	var bestBits = -1
+	for _, entry := range registry.entries {
+		for _, key := range entry.keys {
+			_, ipNet, err := net.ParseCIDR(key)
+			if err != nil || !ipNet.Contains(parsed) {
+				continue
+			}
+			bits, _ := ipNet.Mask.Size()
+			if bits > bestBits {
+				bestBits = bits
+				bestURLs = entry.urls
+			}
+		}
+	}
+
+	if bestURLs == nil {
+		return "", fmt.Errorf("no RDAP bootstrap entry for IP %q", ip)
+	}
+
+	return pickURL(bestURLs)
+}
+
+// ASNServer returns the RDAP base URL responsible for the given AS number
+func (b *RDAPBootstrap) ASNServer(asn string) (string, error) {
+	asn = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(asn)), "AS")
+	number, err := strconv.ParseUint(asn, 10, 32)
+	if err != nil {
+		return "", fmt.Errorf("invalid AS number %q", asn)
+	}
+
+	registry, err := b.registry("asn")
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range registry.entries {
+		for _, key := range entry.keys {
+			lo, hi, err := parseASNRange(key)
+			if err != nil {
+				continue
+			}
+			if number >= lo && number <= hi {
+				return pickURL(entry.urls)
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no RDAP bootstrap entry for AS%d", number)
+}
+
+// registry returns the parsed bootstrap registry for kind, loading it from
+// disk cache or fetching it from IANA as needed, and kicking off a
+// background refresh when the cached copy has gone stale
+func (b *RDAPBootstrap) registry(kind string) (*bootstrapRegistry, error) {
+	b.mu.Lock()
+	registry := b.registries[kind]
+	b.mu.Unlock()
+
+	if registry == nil {
+		cached, err := b.loadFromDisk(kind)
+		if err == nil {
+			registry = cached
+			b.mu.Lock()
+			b.registries[kind] = registry
+			b.mu.Unlock()
+		}
+	}
+
+	if registry == nil {
+		fetched, err := b.fetch(kind)
+		if err != nil {
+			return nil, err
+		}
+		registry = fetched
+		b.mu.Lock()
+		b.registries[kind] = registry
+		b.mu.Unlock()
+		return registry, nil
+	}
+
+	if time.Now().After(registry.expiresAt) {
+		b.refreshInBackground(kind)
+	}
+
+	return registry, nil
+}
+
+// refreshInBackground fetches a fresh copy of kind without blocking the
+// caller, which continues to use the stale-but-present registry
+func (b *RDAPBootstrap) refreshInBackground(kind string) {
+	b.mu.Lock()
+	if b.refreshing[kind] {
+		b.mu.Unlock()
+		return
+	}
+	b.refreshing[kind] = true
+	b.mu.Unlock()
+
+	go func() {
+		defer func() {
+			b.mu.Lock()
+			b.refreshing[kind] = false
+			b.mu.Unlock()
+		}()
+
+		fresh, err := b.fetch(kind)
+		if err != nil {
+			return
+		}
+		b.mu.Lock()
+		b.registries[kind] = fresh
+		b.mu.Unlock()
+	}()
+}
+
+// fetch downloads and parses a bootstrap file from IANA and persists it to disk
+func (b *RDAPBootstrap) fetch(kind string) (*bootstrapRegistry, error) {
+	resp, err := b.httpClient.Get(bootstrapBaseURL + kind + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s bootstrap: %w", kind, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s bootstrap: unexpected status %s", kind, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s bootstrap: %w", kind, err)
+	}
+
+	var file rdapBootstrapFile
+	if err := json.Unmarshal(body, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s bootstrap: %w", kind, err)
+	}
+
+	registry, err := parseBootstrapFile(&file, bootstrapTTLFromHeaders(resp.Header, b.ttl()))
+	if err != nil {
+		return nil, err
+	}
+
+	// best effort, a read-only cache dir shouldn't break bootstrap resolution
+	_ = b.saveToDisk(kind, body)
+
+	return registry, nil
+}
+
+// loadFromDisk reads a previously cached bootstrap file, returning an error
+// if it is missing or unreadable. It does not reject a stale file: the
+// returned registry's expiresAt is derived from the file's mtime, and it's
+// up to registry() to decide whether that's recent enough to serve without
+// kicking off a background refresh.
+func (b *RDAPBootstrap) loadFromDisk(kind string) (*bootstrapRegistry, error) {
+	path := filepath.Join(b.CacheDir, kind+".json")
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file rdapBootstrapFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	expiresAt := info.ModTime().Add(b.ttl())
+	return parseBootstrapFile(&file, expiresAt)
+}
+
+// saveToDisk caches the raw bootstrap file body under CacheDir
+func (b *RDAPBootstrap) saveToDisk(kind string, body []byte) error {
+	if err := os.MkdirAll(b.CacheDir, 0o755); err != nil {
+		return err
+	}
+
+	path := filepath.Join(b.CacheDir, kind+".json")
+	return os.WriteFile(path, body, 0o644)
+}
+
+func (b *RDAPBootstrap) ttl() time.Duration {
+	if b.TTL > 0 {
+		return b.TTL
+	}
+	return defaultBootstrapTTL
+}
+
+// bootstrapTTLFromHeaders derives an expiry time from the Cache-Control
+// max-age header, falling back to now plus def when none is present
+func bootstrapTTLFromHeaders(header http.Header, def time.Duration) time.Time {
+	if cc := header.Get("Cache-Control"); cc != "" {
+		for _, directive := range strings.Split(cc, ",") {
+			directive = strings.TrimSpace(directive)
+			if strings.HasPrefix(directive, "max-age=") {
+				if secs, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil {
+					return time.Now().Add(time.Duration(secs) * time.Second)
+				}
+			}
+		}
+	}
+	return time.Now().Add(def)
+}
+
+// parseBootstrapFile converts the raw JSON services array into bootstrapEntry values
+func parseBootstrapFile(file *rdapBootstrapFile, expiresAt time.Time) (*bootstrapRegistry, error) {
+	registry := &bootstrapRegistry{expiresAt: expiresAt}
+
+	for _, service := range file.Services {
+		if len(service) != 2 {
+			continue
+		}
+
+		var keys, urls []string
+		if err := json.Unmarshal(service[0], &keys); err != nil {
+			continue
+		}
+		if err := json.Unmarshal(service[1], &urls); err != nil {
+			continue
+		}
+
+		registry.entries = append(registry.entries, bootstrapEntry{keys: keys, urls: urls})
+	}
+
+	return registry, nil
+}
+
+// pickURL prefers an https URL if one is offered, otherwise the first URL
+func pickURL(urls []string) (string, error) {
+	if len(urls) == 0 {
+		return "", fmt.Errorf("bootstrap entry has no URLs")
+	}
+
+	for _, u := range urls {
+		if strings.HasPrefix(u, "https://") {
+			return strings.TrimRight(u, "/"), nil
+		}
+	}
+
+	return strings.TrimRight(urls[0], "/"), nil
+}
+
+// parseASNRange parses a bootstrap ASN key, which is either a single number
+// ("733") or a hyphenated range ("733-767")
+func parseASNRange(key string) (lo, hi uint64, err error) {
+	if i := strings.Index(key, "-"); i >= 0 {
+		lo, err = strconv.ParseUint(key[:i], 10, 32)
+		if err != nil {
+			return 0, 0, err
+		}
+		hi, err = strconv.ParseUint(key[i+1:], 10, 32)
+		return lo, hi, err
+	}
+
+	n, err := strconv.ParseUint(key, 10, 32)
+	return n, n, err
+}
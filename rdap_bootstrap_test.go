@@ -0,0 +1,82 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * IANA RDAP bootstrap registry tests for whois package
+ */
+
+package whois
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBootstrapFile(t *testing.T) {
+	raw := []byte(`{
+		"version": "1.0",
+		"publication": "2024-01-01T00:00:00Z",
+		"services": [
+			[["com", "net"], ["https://rdap.verisign.com/com/v1/"]],
+			[["org"], ["https://rdap.publicinterestregistry.org/rdap/"]]
+		]
+	}`)
+
+	var file rdapBootstrapFile
+	assert.Nil(t, json.Unmarshal(raw, &file))
+
+	registry, err := parseBootstrapFile(&file, time.Now().Add(time.Hour))
+	assert.Nil(t, err)
+	assert.Len(t, registry.entries, 2)
+	assert.Equal(t, []string{"com", "net"}, registry.entries[0].keys)
+	assert.Equal(t, []string{"https://rdap.verisign.com/com/v1/"}, registry.entries[0].urls)
+}
+
+func TestPickURL(t *testing.T) {
+	url, err := pickURL([]string{"http://example.org/", "https://example.org/"})
+	assert.Nil(t, err)
+	assert.Equal(t, "https://example.org", url)
+
+	url, err = pickURL([]string{"http://example.org/"})
+	assert.Nil(t, err)
+	assert.Equal(t, "http://example.org", url)
+
+	_, err = pickURL(nil)
+	assert.NotNil(t, err)
+}
+
+func TestParseASNRange(t *testing.T) {
+	lo, hi, err := parseASNRange("733-767")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(733), lo)
+	assert.Equal(t, uint64(767), hi)
+
+	lo, hi, err = parseASNRange("15169")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(15169), lo)
+	assert.Equal(t, uint64(15169), hi)
+
+	_, _, err = parseASNRange("not-a-number")
+	assert.NotNil(t, err)
+}
+
+func TestNewRDAPBootstrap(t *testing.T) {
+	bootstrap := NewRDAPBootstrap()
+	assert.NotNil(t, bootstrap)
+	assert.NotEmpty(t, bootstrap.CacheDir)
+	assert.Equal(t, defaultBootstrapTTL, bootstrap.TTL)
+}
@@ -0,0 +1,341 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * DNSSEC cross-verification of RDAP secureDNS against live DNS
+ */
+
+package whois
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// dnsTypeDS is the DNS RR type value for a DS record (RFC 4034)
+const dnsTypeDS = 43
+
+// DNS RCODE values (RFC 1035 section 4.1.1) that decodeDSResponse
+// distinguishes from success. A non-zero RCODE must not be treated as "no
+// DS records": a SERVFAIL (broken DNSSEC validation chain) or REFUSED
+// response has ANCOUNT=0 too, and reporting that the same way as a
+// genuinely empty, well-formed answer would hide the exact failure a
+// DNSSEC audit is meant to catch.
+const (
+	dnsRcodeNoError  = 0
+	dnsRcodeFormErr  = 1
+	dnsRcodeServFail = 2
+	dnsRcodeNXDomain = 3
+	dnsRcodeNotImp   = 4
+	dnsRcodeRefused  = 5
+)
+
+// dnsRcodeString names an RCODE for error messages, falling back to its
+// numeric value for codes this package doesn't special-case
+func dnsRcodeString(rcode uint16) string {
+	switch rcode {
+	case dnsRcodeFormErr:
+		return "FORMERR"
+	case dnsRcodeServFail:
+		return "SERVFAIL"
+	case dnsRcodeNXDomain:
+		return "NXDOMAIN"
+	case dnsRcodeNotImp:
+		return "NOTIMP"
+	case dnsRcodeRefused:
+		return "REFUSED"
+	default:
+		return fmt.Sprintf("RCODE %d", rcode)
+	}
+}
+
+// DNSResolver resolves the DS RRset published for a domain in its parent
+// zone, so it can be compared against what a registry's RDAP response
+// claims. Implementations report whether the answer came back with the
+// DNS "Authentic Data" (AD) bit set.
+type DNSResolver interface {
+	LookupDS(ctx context.Context, domain string) (records []RDAPDS, authenticated bool, err error)
+}
+
+// SecureDNSVerification is the result of comparing an RDAPResponse's
+// SecureDNS.DSData against the DS RRset actually published in the parent
+// zone
+type SecureDNSVerification struct {
+	// Matched are registry DS records with an identical DS record published in DNS
+	Matched []RDAPDS
+	// Mismatched are registry DS records that share a key tag with a
+	// published DS record, but differ in algorithm/digest type/digest
+	Mismatched []RDAPDS
+	// Missing are registry DS records with no published DS record sharing their key tag
+	Missing []RDAPDS
+	// Published is the full DS RRset observed in the parent zone
+	Published []RDAPDS
+	// Authenticated reports whether the parent zone's response was AD-flagged
+	Authenticated bool
+}
+
+// VerifySecureDNS resolves the DS RRset for the domain r describes and
+// compares it against r.SecureDNS.DSData, proving (rather than merely
+// reporting) what the registry claims about DNSSEC delegation
+func (r *RDAPResponse) VerifySecureDNS(ctx context.Context, resolver DNSResolver) (*SecureDNSVerification, error) {
+	if r.SecureDNS == nil || len(r.SecureDNS.DSData) == 0 {
+		return nil, fmt.Errorf("RDAP response has no secureDNS DS data to verify")
+	}
+
+	domain := r.LdhName
+	if domain == "" {
+		return nil, fmt.Errorf("RDAP response has no domain name to resolve DS records for")
+	}
+
+	published, authenticated, err := resolver.LookupDS(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve DS records for %s: %w", domain, err)
+	}
+
+	result := &SecureDNSVerification{Published: published, Authenticated: authenticated}
+
+	for _, claimed := range r.SecureDNS.DSData {
+		_, ok := findDSByKeyTag(published, claimed.KeyTag)
+		identical := false
+		for _, p := range published {
+			if p.KeyTag == claimed.KeyTag && dsEqual(claimed, p) {
+				identical = true
+				break
+			}
+		}
+		switch {
+		case !ok:
+			result.Missing = append(result.Missing, claimed)
+		case identical:
+			result.Matched = append(result.Matched, claimed)
+		default:
+			result.Mismatched = append(result.Mismatched, claimed)
+		}
+	}
+
+	return result, nil
+}
+
+func findDSByKeyTag(records []RDAPDS, keyTag int) (*RDAPDS, bool) {
+	for i := range records {
+		if records[i].KeyTag == keyTag {
+			return &records[i], true
+		}
+	}
+	return nil, false
+}
+
+func dsEqual(a, b RDAPDS) bool {
+	return a.Algorithm == b.Algorithm &&
+		a.DigestType == b.DigestType &&
+		strings.EqualFold(a.Digest, b.Digest)
+}
+
+// DefaultDNSResolver is a dependency-free DNSResolver that speaks just
+// enough of the DNS wire format (RFC 1035) to issue a DS query over UDP
+// and read back the AD bit and any DS records in the answer section.
+//
+// It sends no EDNS0 OPT record, so it never sets the DNSSEC OK (DO) bit:
+// most validating resolvers only bother setting the Authentic Data (AD)
+// bit on replies to DO-flagged queries, so Authenticated will often come
+// back false even when the answer is in fact validated. It also doesn't
+// retry over TCP when a reply comes back with TC=1 (truncated), so a
+// DS RRset that doesn't fit a single UDP datagram may be read back
+// incomplete. Callers that need a trustworthy AD bit or large RRsets
+// should build with the miekg build tag and use MiekgDNSResolver instead.
+type DefaultDNSResolver struct {
+	// Server is the "host:port" of the resolver to query
+	Server string
+	// Timeout bounds the UDP round trip
+	Timeout time.Duration
+}
+
+// NewDefaultDNSResolver returns a DefaultDNSResolver querying a public
+// resolver with a reasonable timeout
+func NewDefaultDNSResolver() *DefaultDNSResolver {
+	return &DefaultDNSResolver{Server: "1.1.1.1:53", Timeout: defaultTimeout}
+}
+
+// LookupDS implements DNSResolver
+func (d *DefaultDNSResolver) LookupDS(ctx context.Context, domain string) ([]RDAPDS, bool, error) {
+	query, id := encodeDSQuery(domain)
+
+	dialer := net.Dialer{Timeout: d.timeout()}
+	conn, err := dialer.DialContext(ctx, "udp", d.server())
+	if err != nil {
+		return nil, false, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else {
+		conn.SetDeadline(time.Now().Add(d.timeout()))
+	}
+
+	if _, err := conn.Write(query); err != nil {
+		return nil, false, err
+	}
+
+	buf := make([]byte, 4096)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return decodeDSResponse(buf[:n], id)
+}
+
+func (d *DefaultDNSResolver) server() string {
+	if d.Server != "" {
+		return d.Server
+	}
+	return "1.1.1.1:53"
+}
+
+func (d *DefaultDNSResolver) timeout() time.Duration {
+	if d.Timeout > 0 {
+		return d.Timeout
+	}
+	return defaultTimeout
+}
+
+// encodeDSQuery builds a minimal DNS query message asking for the DS
+// record of domain, returning the message and its transaction ID
+func encodeDSQuery(domain string) ([]byte, uint16) {
+	id := uint16(time.Now().UnixNano())
+
+	msg := make([]byte, 12)
+	binary.BigEndian.PutUint16(msg[0:2], id)
+	binary.BigEndian.PutUint16(msg[2:4], 0x0100) // RD=1
+	binary.BigEndian.PutUint16(msg[4:6], 1)      // QDCOUNT
+
+	msg = append(msg, encodeDNSName(domain)...)
+	qtypeAndClass := make([]byte, 4)
+	binary.BigEndian.PutUint16(qtypeAndClass[0:2], dnsTypeDS)
+	binary.BigEndian.PutUint16(qtypeAndClass[2:4], 1) // IN
+	msg = append(msg, qtypeAndClass...)
+
+	return msg, id
+}
+
+// encodeDNSName encodes domain as a sequence of length-prefixed labels
+// terminated by a zero-length label
+func encodeDNSName(domain string) []byte {
+	var out []byte
+	for _, label := range strings.Split(strings.TrimSuffix(domain, "."), ".") {
+		out = append(out, byte(len(label)))
+		out = append(out, []byte(label)...)
+	}
+	return append(out, 0)
+}
+
+// decodeDSResponse parses a DNS response message, returning any DS
+// records in the answer section and whether the AD bit was set
+func decodeDSResponse(msg []byte, wantID uint16) ([]RDAPDS, bool, error) {
+	if len(msg) < 12 {
+		return nil, false, fmt.Errorf("DNS response too short")
+	}
+	if binary.BigEndian.Uint16(msg[0:2]) != wantID {
+		return nil, false, fmt.Errorf("DNS response ID mismatch")
+	}
+
+	flags := binary.BigEndian.Uint16(msg[2:4])
+	authenticated := flags&0x0020 != 0
+
+	if rcode := flags & 0x000F; rcode != dnsRcodeNoError {
+		return nil, authenticated, fmt.Errorf("DNS response for DS query returned %s", dnsRcodeString(rcode))
+	}
+
+	qdcount := binary.BigEndian.Uint16(msg[4:6])
+	ancount := binary.BigEndian.Uint16(msg[6:8])
+
+	offset := 12
+	for i := 0; i < int(qdcount); i++ {
+		var err error
+		offset, err = skipDNSName(msg, offset)
+		if err != nil {
+			return nil, authenticated, err
+		}
+		offset += 4 // QTYPE + QCLASS
+	}
+
+	var records []RDAPDS
+	for i := 0; i < int(ancount); i++ {
+		var err error
+		var rrType uint16
+		var rdata []byte
+		offset, rrType, rdata, err = readResourceRecord(msg, offset)
+		if err != nil {
+			return records, authenticated, err
+		}
+		if rrType == dnsTypeDS && len(rdata) >= 4 {
+			records = append(records, RDAPDS{
+				KeyTag:     int(binary.BigEndian.Uint16(rdata[0:2])),
+				Algorithm:  int(rdata[2]),
+				DigestType: int(rdata[3]),
+				Digest:     strings.ToUpper(hex.EncodeToString(rdata[4:])),
+			})
+		}
+	}
+
+	return records, authenticated, nil
+}
+
+// skipDNSName advances past a (possibly compressed) DNS name starting at
+// offset and returns the offset immediately after it
+func skipDNSName(msg []byte, offset int) (int, error) {
+	for {
+		if offset >= len(msg) {
+			return 0, fmt.Errorf("DNS name runs past end of message")
+		}
+
+		length := int(msg[offset])
+		switch {
+		case length == 0:
+			return offset + 1, nil
+		case length&0xC0 == 0xC0: // compression pointer
+			return offset + 2, nil
+		default:
+			offset += 1 + length
+		}
+	}
+}
+
+// readResourceRecord parses one resource record starting at offset,
+// returning the offset just past it, its TYPE and its RDATA
+func readResourceRecord(msg []byte, offset int) (next int, rrType uint16, rdata []byte, err error) {
+	offset, err = skipDNSName(msg, offset)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	if offset+10 > len(msg) {
+		return 0, 0, nil, fmt.Errorf("resource record header runs past end of message")
+	}
+
+	rrType = binary.BigEndian.Uint16(msg[offset : offset+2])
+	rdlength := int(binary.BigEndian.Uint16(msg[offset+8 : offset+10]))
+	offset += 10
+
+	if offset+rdlength > len(msg) {
+		return 0, 0, nil, fmt.Errorf("resource record data runs past end of message")
+	}
+
+	return offset + rdlength, rrType, msg[offset : offset+rdlength], nil
+}
@@ -0,0 +1,89 @@
+//go:build miekg
+
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * miekg/dns-backed DNSSEC resolver with EDNS0/DO support
+ */
+
+package whois
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// MiekgDNSResolver is a DNSResolver backed by github.com/miekg/dns. Unlike
+// DefaultDNSResolver it sets EDNS0 with the DNSSEC OK (DO) bit, so
+// validating resolvers return RRSIGs and the AD bit reliably
+type MiekgDNSResolver struct {
+	// Server is the "host:port" of the resolver to query
+	Server string
+	// Timeout bounds the query round trip
+	Timeout time.Duration
+}
+
+// NewMiekgDNSResolver returns a MiekgDNSResolver querying a public
+// validating resolver with a reasonable timeout
+func NewMiekgDNSResolver() *MiekgDNSResolver {
+	return &MiekgDNSResolver{Server: "1.1.1.1:53", Timeout: defaultTimeout}
+}
+
+// LookupDS implements DNSResolver
+func (m *MiekgDNSResolver) LookupDS(ctx context.Context, domain string) ([]RDAPDS, bool, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(domain), dns.TypeDS)
+	msg.SetEdns0(4096, true) // true requests DNSSEC OK (DO)
+
+	client := &dns.Client{Timeout: m.timeout()}
+
+	reply, _, err := client.ExchangeContext(ctx, msg, m.server())
+	if err != nil {
+		return nil, false, fmt.Errorf("DS query for %s failed: %w", domain, err)
+	}
+
+	var records []RDAPDS
+	for _, rr := range reply.Answer {
+		ds, ok := rr.(*dns.DS)
+		if !ok {
+			continue
+		}
+		records = append(records, RDAPDS{
+			KeyTag:     int(ds.KeyTag),
+			Algorithm:  int(ds.Algorithm),
+			DigestType: int(ds.DigestType),
+			Digest:     ds.Digest,
+		})
+	}
+
+	return records, reply.AuthenticatedData, nil
+}
+
+func (m *MiekgDNSResolver) server() string {
+	if m.Server != "" {
+		return m.Server
+	}
+	return "1.1.1.1:53"
+}
+
+func (m *MiekgDNSResolver) timeout() time.Duration {
+	if m.Timeout > 0 {
+		return m.Timeout
+	}
+	return defaultTimeout
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * DNSSEC cross-verification tests for whois package
+ */
+
+package whois
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDNSResolver struct {
+	records       []RDAPDS
+	authenticated bool
+	err           error
+}
+
+func (f *fakeDNSResolver) LookupDS(ctx context.Context, domain string) ([]RDAPDS, bool, error) {
+	return f.records, f.authenticated, f.err
+}
+
+func TestVerifySecureDNSMatchedMismatchedMissing(t *testing.T) {
+	response := &RDAPResponse{
+		LdhName: "example.com",
+		SecureDNS: &RDAPSecureDNS{
+			DelegationSigned: true,
+			DSData: []RDAPDS{
+				{KeyTag: 1, Algorithm: 13, DigestType: 2, Digest: "AAAA"},
+				{KeyTag: 2, Algorithm: 13, DigestType: 2, Digest: "BBBB"},
+				{KeyTag: 3, Algorithm: 13, DigestType: 2, Digest: "CCCC"},
+			},
+		},
+	}
+
+	resolver := &fakeDNSResolver{
+		authenticated: true,
+		records: []RDAPDS{
+			{KeyTag: 1, Algorithm: 13, DigestType: 2, Digest: "aaaa"}, // matches, case-insensitive
+			{KeyTag: 2, Algorithm: 13, DigestType: 2, Digest: "ZZZZ"}, // same key tag, different digest
+			// key tag 3 missing entirely
+		},
+	}
+
+	result, err := response.VerifySecureDNS(context.Background(), resolver)
+	assert.Nil(t, err)
+	assert.True(t, result.Authenticated)
+	assert.Len(t, result.Matched, 1)
+	assert.Equal(t, 1, result.Matched[0].KeyTag)
+	assert.Len(t, result.Mismatched, 1)
+	assert.Equal(t, 2, result.Mismatched[0].KeyTag)
+	assert.Len(t, result.Missing, 1)
+	assert.Equal(t, 3, result.Missing[0].KeyTag)
+}
+
+func TestVerifySecureDNSRequiresDSData(t *testing.T) {
+	response := &RDAPResponse{LdhName: "example.com"}
+	_, err := response.VerifySecureDNS(context.Background(), &fakeDNSResolver{})
+	assert.NotNil(t, err)
+}
+
+func TestEncodeDNSName(t *testing.T) {
+	encoded := encodeDNSName("example.com")
+	assert.Equal(t, []byte{7, 'e', 'x', 'a', 'm', 'p', 'l', 'e', 3, 'c', 'o', 'm', 0}, encoded)
+}
+
+func TestDecodeDSResponseRoundTrip(t *testing.T) {
+	query, id := encodeDSQuery("example.com")
+
+	msg := make([]byte, len(query))
+	copy(msg, query)
+	msg[2] = 0x81 // QR=1, RD=1
+	msg[3] = 0x20 // AD=1
+	// ANCOUNT = 1
+	msg[6] = 0
+	msg[7] = 1
+
+	// answer: name pointer to offset 12 (the question name), type DS,
+	// class IN, TTL, RDLENGTH, RDATA
+	answer := []byte{0xC0, 0x0C, 0, 43, 0, 1, 0, 0, 0, 60, 0, 8}
+	rdata := []byte{0, 1, 13, 2, 0xDE, 0xAD, 0xBE, 0xEF}
+	msg = append(msg, answer...)
+	msg = append(msg, rdata...)
+
+	records, authenticated, err := decodeDSResponse(msg, id)
+	assert.Nil(t, err)
+	assert.True(t, authenticated)
+	assert.Len(t, records, 1)
+	assert.Equal(t, 1, records[0].KeyTag)
+	assert.Equal(t, 13, records[0].Algorithm)
+	assert.Equal(t, 2, records[0].DigestType)
+	assert.Equal(t, "DEADBEEF", records[0].Digest)
+}
+
+func TestDecodeDSResponseRejectsNonNoErrorRcode(t *testing.T) {
+	query, id := encodeDSQuery("example.com")
+
+	msg := make([]byte, len(query))
+	copy(msg, query)
+	msg[2] = 0x81 // QR=1, RD=1
+	msg[3] = 0x02 // RCODE=2 (SERVFAIL), ANCOUNT left at 0
+
+	_, _, err := decodeDSResponse(msg, id)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "SERVFAIL")
+}
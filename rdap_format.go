@@ -0,0 +1,201 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * WHOIS-style, JSON and table rendering of RDAPResponse
+ */
+
+package whois
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// FormatStyle selects how RDAPResponse.Format renders a response
+type FormatStyle int
+
+// Supported FormatStyle values
+const (
+	StyleWhois FormatStyle = iota
+	StyleJSON
+	StyleTable
+)
+
+// rdapEventLabels maps RDAP eventAction values onto the key labels
+// ICANN's gTLD WHOIS output uses
+var rdapEventLabels = map[string]string{
+	"registration": "Creation Date",
+	"expiration":   "Registry Expiry Date",
+	"last changed": "Updated Date",
+}
+
+// rdapRoleLabels maps RDAP entity roles onto the prefix WHOIS output uses
+// for that role's fields
+var rdapRoleLabels = map[string]string{
+	"registrar":      "Registrar",
+	"registrant":     "Registrant",
+	"administrative": "Admin",
+	"technical":      "Tech",
+	"abuse":          "Abuse",
+	"billing":        "Billing",
+}
+
+// ToWhoisText renders response as traditional key-colon-value WHOIS text,
+// the format ops teams and log parsers still expect
+func (r *RDAPResponse) ToWhoisText() string {
+	var buf bytes.Buffer
+	r.writeWhoisText(&buf)
+	return buf.String()
+}
+
+// Format renders response to w in the requested style
+func (r *RDAPResponse) Format(w io.Writer, style FormatStyle) error {
+	switch style {
+	case StyleJSON:
+		data, err := r.ToJSON()
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case StyleTable:
+		return r.writeTable(w)
+	default:
+		_, err := io.WriteString(w, r.ToWhoisText())
+		return err
+	}
+}
+
+func (r *RDAPResponse) writeWhoisText(buf *bytes.Buffer) {
+	switch r.ObjectClassName {
+	case "domain":
+		fmt.Fprintf(buf, "Domain Name: %s\n", strings.ToUpper(r.LdhName))
+	case "ip network":
+		fmt.Fprintf(buf, "NetRange: %s - %s\n", r.StartAddress, r.EndAddress)
+		if r.Name != "" {
+			fmt.Fprintf(buf, "NetName: %s\n", r.Name)
+		}
+	case "autnum":
+		fmt.Fprintf(buf, "ASNumber: %s\n", r.Autnum)
+		if r.Name != "" {
+			fmt.Fprintf(buf, "ASName: %s\n", r.Name)
+		}
+	default:
+		if r.Name != "" {
+			fmt.Fprintf(buf, "Name: %s\n", r.Name)
+		}
+	}
+
+	if r.Handle != "" {
+		fmt.Fprintf(buf, "Registry Domain ID: %s\n", r.Handle)
+	}
+
+	for _, entity := range r.Entities {
+		writeEntityWhoisText(buf, entity)
+	}
+
+	for _, event := range r.Events {
+		label := rdapEventLabels[event.EventAction]
+		if label == "" {
+			label = strings.Title(event.EventAction) + " Date"
+		}
+		fmt.Fprintf(buf, "%s: %s\n", label, event.EventDate)
+	}
+
+	for _, ns := range r.Nameservers {
+		fmt.Fprintf(buf, "Name Server: %s\n", strings.ToUpper(ns.LdhName))
+	}
+
+	if r.SecureDNS != nil {
+		if r.SecureDNS.DelegationSigned {
+			fmt.Fprintln(buf, "DNSSEC: signedDelegation")
+		} else {
+			fmt.Fprintln(buf, "DNSSEC: unsigned")
+		}
+	}
+
+	for _, status := range r.Status {
+		fmt.Fprintf(buf, "Domain Status: %s\n", status)
+	}
+
+	for _, remark := range r.Remarks {
+		for _, line := range remark.Description {
+			fmt.Fprintf(buf, ">>> %s\n", line)
+		}
+	}
+}
+
+// writeEntityWhoisText renders one entity's fields under the WHOIS label
+// for each of its roles, e.g. "Registrar:", "Registrant Name:", "Admin Email:"
+func writeEntityWhoisText(buf *bytes.Buffer, entity RDAPEntity) {
+	roles := entity.Roles
+	if len(roles) == 0 {
+		roles = []string{""}
+	}
+
+	for _, role := range roles {
+		prefix := rdapRoleLabels[role]
+		if prefix == "" {
+			prefix = strings.Title(role)
+		}
+
+		if entity.Handle != "" {
+			fmt.Fprintf(buf, "%s: %s\n", strings.TrimSpace(prefix), entity.Handle)
+		}
+		if name := entity.FormattedName(); name != "" {
+			fmt.Fprintf(buf, "%s Name: %s\n", prefix, name)
+		}
+		if org := entity.Organization(); org != "" {
+			fmt.Fprintf(buf, "%s Organization: %s\n", prefix, org)
+		}
+		if email := entity.PreferredEmail(); email != "" {
+			fmt.Fprintf(buf, "%s Email: %s\n", prefix, email)
+		}
+		if len(entity.PhoneNumbers) > 0 {
+			fmt.Fprintf(buf, "%s Phone: %s\n", prefix, entity.PhoneNumbers[0].Value)
+		}
+	}
+}
+
+// writeTable renders response as an aligned key/value table
+func (r *RDAPResponse) writeTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Object Class\t%s\n", r.ObjectClassName)
+	if r.LdhName != "" {
+		fmt.Fprintf(tw, "Name\t%s\n", r.LdhName)
+	}
+	if r.Handle != "" {
+		fmt.Fprintf(tw, "Handle\t%s\n", r.Handle)
+	}
+	for _, event := range r.Events {
+		label := rdapEventLabels[event.EventAction]
+		if label == "" {
+			label = event.EventAction
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", label, event.EventDate)
+	}
+	for _, ns := range r.Nameservers {
+		fmt.Fprintf(tw, "Name Server\t%s\n", ns.LdhName)
+	}
+	for _, status := range r.Status {
+		fmt.Fprintf(tw, "Status\t%s\n", status)
+	}
+
+	return tw.Flush()
+}
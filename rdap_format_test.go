@@ -0,0 +1,76 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * WHOIS-style rendering tests for whois package
+ */
+
+package whois
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRDAPDomainResponse() *RDAPResponse {
+	return &RDAPResponse{
+		ObjectClassName: "domain",
+		LdhName:         "example.com",
+		Entities: []RDAPEntity{
+			{
+				ObjectClassName: "entity",
+				Handle:          "EXAMPLE-REGISTRAR",
+				Roles:           []string{"registrar"},
+				FullName:        "Example Registrar, Inc.",
+			},
+		},
+		Events: []RDAPEvent{
+			{EventAction: "registration", EventDate: "2020-01-01T00:00:00Z"},
+			{EventAction: "expiration", EventDate: "2030-01-01T00:00:00Z"},
+		},
+		Nameservers: []RDAPNameserver{
+			{ObjectClassName: "nameserver", LdhName: "ns1.example.com"},
+		},
+		SecureDNS: &RDAPSecureDNS{DelegationSigned: true},
+		Status:    []string{"active"},
+	}
+}
+
+func TestToWhoisText(t *testing.T) {
+	text := testRDAPDomainResponse().ToWhoisText()
+
+	assert.Contains(t, text, "Domain Name: EXAMPLE.COM")
+	assert.Contains(t, text, "Registrar: EXAMPLE-REGISTRAR")
+	assert.Contains(t, text, "Registrar Name: Example Registrar, Inc.")
+	assert.Contains(t, text, "Creation Date: 2020-01-01T00:00:00Z")
+	assert.Contains(t, text, "Registry Expiry Date: 2030-01-01T00:00:00Z")
+	assert.Contains(t, text, "Name Server: NS1.EXAMPLE.COM")
+	assert.Contains(t, text, "DNSSEC: signedDelegation")
+	assert.Contains(t, text, "Domain Status: active")
+}
+
+func TestFormatStyleJSON(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, testRDAPDomainResponse().Format(&buf, StyleJSON))
+	assert.Contains(t, buf.String(), `"ldhName":"example.com"`)
+}
+
+func TestFormatStyleTable(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, testRDAPDomainResponse().Format(&buf, StyleTable))
+	assert.Contains(t, buf.String(), "Object Class")
+	assert.Contains(t, buf.String(), "example.com")
+}
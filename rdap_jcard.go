@@ -0,0 +1,259 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * jCard (RFC 7095) parsing for RDAP entities
+ */
+
+package whois
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// UnmarshalJSON decodes an RDAPEntity the normal way and then parses its
+// vcardArray into the typed contact fields, so callers never have to
+// hand-walk []interface{} themselves
+func (e *RDAPEntity) UnmarshalJSON(data []byte) error {
+	type rdapEntityAlias RDAPEntity
+
+	var alias rdapEntityAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+
+	*e = RDAPEntity(alias)
+	e.parseVCard()
+
+	return nil
+}
+
+// parseVCard walks e.VCardArray, a jCard (RFC 7095) structure shaped as
+// ["vcard", [ [name, params, valueType, value], ... ] ], and populates the
+// entity's typed contact fields from it. It only parses e itself; nested
+// entities are handled separately by the caller (UnmarshalJSON relies on
+// encoding/json recursing into them on its own, and populateVCards walks
+// them explicitly for entities built outside of JSON decoding), so calling
+// this twice on the same entity would double up its phone/email/address
+// slices.
+func (e *RDAPEntity) parseVCard() {
+	if len(e.VCardArray) != 2 {
+		return
+	}
+
+	properties, ok := e.VCardArray[1].([]interface{})
+	if !ok {
+		return
+	}
+
+	for _, raw := range properties {
+		prop, ok := raw.([]interface{})
+		if !ok || len(prop) < 4 {
+			continue
+		}
+
+		name, _ := prop[0].(string)
+		params, _ := prop[1].(map[string]interface{})
+		value := prop[3]
+
+		switch strings.ToLower(name) {
+		case "fn":
+			e.FullName = vcardString(value)
+		case "n":
+			e.Name = parseVCardName(value)
+		case "org":
+			e.OrganizationName = vcardOrganization(value)
+		case "adr":
+			if addr := parseVCardAddress(value, params); addr != nil {
+				e.Addresses = append(e.Addresses, *addr)
+			}
+		case "tel":
+			e.PhoneNumbers = append(e.PhoneNumbers, RDAPPhoneNumber{
+				Type:  vcardTelType(params),
+				Value: vcardString(value),
+			})
+		case "email":
+			e.EmailAddresses = append(e.EmailAddresses, RDAPEmailAddress{
+				Type:  vcardParamString(params, "type"),
+				Value: vcardString(value),
+			})
+		case "kind":
+			e.Kind = vcardString(value)
+		case "lang":
+			e.Lang = vcardString(value)
+		case "role":
+			e.JobTitle = vcardString(value)
+		}
+	}
+}
+
+// populateVCards calls parseVCard on every entity in entities and,
+// recursively, on their nested entities. UnmarshalJSON doesn't need this
+// itself (encoding/json already invokes RDAPEntity.UnmarshalJSON for each
+// nested entity as it decodes), but entities built outside of JSON
+// decoding, such as convertWhoisToRDAP's synthesized entities, need it
+// applied explicitly.
+func populateVCards(entities []RDAPEntity) {
+	for i := range entities {
+		entities[i].parseVCard()
+		populateVCards(entities[i].Entities)
+	}
+}
+
+// vcardString coerces a jCard property value to a string, which is how
+// text-valued properties (fn, tel, email, kind, lang, role) are encoded
+func vcardString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return ""
+}
+
+// vcardStringSlice coerces a jCard structured value component, which may be
+// a bare string or a single-element array of strings, into one string
+func vcardStringSlice(component interface{}) string {
+	switch v := component.(type) {
+	case string:
+		return v
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, " ")
+	default:
+		return ""
+	}
+}
+
+// parseVCardName parses the "n" property's five structured components:
+// family, given, additional, prefixes, suffixes
+func parseVCardName(value interface{}) *RDAPName {
+	components, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	name := &RDAPName{}
+	for i, component := range components {
+		s := vcardStringSlice(component)
+		switch i {
+		case 0:
+			name.Family = s
+		case 1:
+			name.Given = s
+		case 2:
+			name.Additional = s
+		case 3:
+			name.Prefixes = s
+		case 4:
+			name.Suffixes = s
+		}
+	}
+
+	return name
+}
+
+// vcardOrganization parses the "org" property, whose value is the
+// organization name optionally followed by organizational unit components
+func vcardOrganization(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		if len(v) == 0 {
+			return ""
+		}
+		if s, ok := v[0].(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// parseVCardAddress parses the "adr" property's seven structured
+// components into the existing RDAPAddress shape
+func parseVCardAddress(value interface{}, params map[string]interface{}) *RDAPAddress {
+	components, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	addr := &RDAPAddress{Type: vcardParamString(params, "type")}
+	for i, component := range components {
+		s := vcardStringSlice(component)
+		if s == "" {
+			continue
+		}
+		switch i {
+		case 0:
+			addr.PostOfficeBox = []string{s}
+		case 1:
+			addr.ExtendedAddress = []string{s}
+		case 2:
+			addr.StreetAddress = []string{s}
+		case 3:
+			addr.Locality = []string{s}
+		case 4:
+			addr.Region = []string{s}
+		case 5:
+			addr.PostalCode = []string{s}
+		case 6:
+			addr.CountryName = []string{s}
+		}
+	}
+
+	return addr
+}
+
+// vcardTelType maps the jCard "tel" type parameter (voice/fax/cell) onto
+// RDAPPhoneNumber.Type
+func vcardTelType(params map[string]interface{}) string {
+	return vcardParamString(params, "type")
+}
+
+// vcardParamString reads a jCard property parameter, which may be encoded
+// as a bare string or a single-element array of strings
+func vcardParamString(params map[string]interface{}, key string) string {
+	if params == nil {
+		return ""
+	}
+	return vcardStringSlice(params[key])
+}
+
+// FormattedName returns the entity's display name, preferring the jCard
+// "fn" property and falling back to its RDAP handle
+func (e *RDAPEntity) FormattedName() string {
+	if e.FullName != "" {
+		return e.FullName
+	}
+	return e.Handle
+}
+
+// Organization returns the entity's organization name, as parsed from the
+// jCard "org" property
+func (e *RDAPEntity) Organization() string {
+	return e.OrganizationName
+}
+
+// PreferredEmail returns the entity's first known email address, if any
+func (e *RDAPEntity) PreferredEmail() string {
+	if len(e.EmailAddresses) == 0 {
+		return ""
+	}
+	return e.EmailAddresses[0].Value
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * jCard parsing tests for whois package
+ */
+
+package whois
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testJCard = `{
+	"objectClassName": "entity",
+	"handle": "EXAMPLE-REGISTRAR",
+	"roles": ["registrar"],
+	"vcardArray": [
+		"vcard",
+		[
+			["version", {}, "text", "4.0"],
+			["fn", {}, "text", "Example Registrar, Inc."],
+			["n", {}, "text", ["Registrar", "Example", "", "", ""]],
+			["org", {}, "text", "Example Registrar, Inc."],
+			["adr", {}, "text", ["", "", "123 Main St", "Reston", "VA", "20190", "US"]],
+			["tel", {"type": "voice"}, "uri", "+1.7035555555"],
+			["email", {}, "text", "abuse@example-registrar.test"],
+			["kind", {}, "text", "org"],
+			["lang", {}, "language-tag", "en"],
+			["role", {}, "text", "Technical Contact"]
+		]
+	]
+}`
+
+func TestRDAPEntityParsesVCard(t *testing.T) {
+	var entity RDAPEntity
+	assert.Nil(t, json.Unmarshal([]byte(testJCard), &entity))
+
+	assert.Equal(t, "Example Registrar, Inc.", entity.FullName)
+	assert.Equal(t, "Example Registrar, Inc.", entity.OrganizationName)
+	assert.Equal(t, "org", entity.Kind)
+	assert.Equal(t, "en", entity.Lang)
+	assert.Equal(t, "Technical Contact", entity.JobTitle)
+
+	assert.NotNil(t, entity.Name)
+	assert.Equal(t, "Registrar", entity.Name.Family)
+	assert.Equal(t, "Example", entity.Name.Given)
+
+	assert.Len(t, entity.Addresses, 1)
+	assert.Equal(t, []string{"123 Main St"}, entity.Addresses[0].StreetAddress)
+	assert.Equal(t, []string{"Reston"}, entity.Addresses[0].Locality)
+	assert.Equal(t, []string{"US"}, entity.Addresses[0].CountryName)
+
+	assert.Len(t, entity.PhoneNumbers, 1)
+	assert.Equal(t, "voice", entity.PhoneNumbers[0].Type)
+	assert.Equal(t, "+1.7035555555", entity.PhoneNumbers[0].Value)
+
+	assert.Len(t, entity.EmailAddresses, 1)
+	assert.Equal(t, "abuse@example-registrar.test", entity.EmailAddresses[0].Value)
+
+	assert.Equal(t, "Example Registrar, Inc.", entity.FormattedName())
+	assert.Equal(t, "Example Registrar, Inc.", entity.Organization())
+	assert.Equal(t, "abuse@example-registrar.test", entity.PreferredEmail())
+}
+
+const testNestedJCard = `{
+	"objectClassName": "entity",
+	"handle": "EXAMPLE-REGISTRAR",
+	"roles": ["registrar"],
+	"vcardArray": ["vcard", [["fn", {}, "text", "Example Registrar, Inc."]]],
+	"entities": [
+		{
+			"objectClassName": "entity",
+			"handle": "EXAMPLE-ABUSE",
+			"roles": ["abuse"],
+			"vcardArray": [
+				"vcard",
+				[
+					["fn", {}, "text", "Abuse Contact"],
+					["tel", {"type": "voice"}, "uri", "+1.7035555555"],
+					["email", {}, "text", "abuse@example-registrar.test"]
+				]
+			]
+		}
+	]
+}`
+
+func TestRDAPEntityParsesNestedEntitiesExactlyOnce(t *testing.T) {
+	var entity RDAPEntity
+	assert.Nil(t, json.Unmarshal([]byte(testNestedJCard), &entity))
+
+	assert.Equal(t, "Example Registrar, Inc.", entity.FullName)
+	assert.Len(t, entity.Entities, 1)
+
+	child := entity.Entities[0]
+	assert.Equal(t, "Abuse Contact", child.FullName)
+	assert.Len(t, child.PhoneNumbers, 1)
+	assert.Len(t, child.EmailAddresses, 1)
+	assert.Equal(t, "abuse@example-registrar.test", child.EmailAddresses[0].Value)
+}
+
+func TestRDAPEntityFormattedNameFallsBackToHandle(t *testing.T) {
+	entity := RDAPEntity{Handle: "EXAMPLE-REGISTRAR"}
+	assert.Equal(t, "EXAMPLE-REGISTRAR", entity.FormattedName())
+	assert.Equal(t, "", entity.PreferredEmail())
+}
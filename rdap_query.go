@@ -0,0 +1,204 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * RDAP nameserver/entity/help/search queries (RFC 9082/9083) for whois package
+ */
+
+package whois
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RDAPSearchResponse represents the result of an RDAP search query
+// (domains?, nameservers?, entities?), as defined in RFC 9082
+type RDAPSearchResponse struct {
+	RdapConformance         []string       `json:"rdapConformance"`
+	Notices                 []RDAPNotice   `json:"notices,omitempty"`
+	DomainSearchResults     []RDAPResponse `json:"domainSearchResults,omitempty"`
+	NameserverSearchResults []RDAPResponse `json:"nameserverSearchResults,omitempty"`
+	EntitySearchResults     []RDAPResponse `json:"entitySearchResults,omitempty"`
+}
+
+// rirRDAPServers are the five regional internet registries' RDAP bases,
+// used as a best-effort fallback for entity lookups since IANA does not
+// publish an entity bootstrap registry; entity handles conventionally end
+// in the RIR's suffix (e.g. "SOME-HANDLE-ARIN")
+var rirRDAPServers = map[string]string{
+	"ARIN":    "https://rdap.arin.net/registry",
+	"RIPE":    "https://rdap.db.ripe.net",
+	"APNIC":   "https://rdap.apnic.net",
+	"LACNIC":  "https://rdap.lacnic.net/rdap",
+	"AFRINIC": "https://rdap.afrinic.net/rdap",
+}
+
+// entityServer guesses an entity's authoritative RDAP server from the RIR
+// suffix conventionally appended to its handle
+func entityServer(handle string) (string, error) {
+	upper := strings.ToUpper(handle)
+	for suffix, server := range rirRDAPServers {
+		if strings.HasSuffix(upper, "-"+suffix) {
+			return server, nil
+		}
+	}
+	return "", fmt.Errorf("unable to determine RDAP server for entity handle %q, set RDAPClient.Server", handle)
+}
+
+// QueryNameserver performs an RDAP nameserver lookup for fqdn (RFC 9082 5.2)
+func (rc *RDAPClient) QueryNameserver(fqdn string) (*RDAPResponse, error) {
+	base, err := rc.baseFor("nameserver", fqdn)
+	if err != nil {
+		return nil, err
+	}
+	return rc.followRDAP(base + "/nameserver/" + fqdn)
+}
+
+// QueryEntity performs an RDAP entity lookup for handle (RFC 9082 5.3)
+func (rc *RDAPClient) QueryEntity(handle string) (*RDAPResponse, error) {
+	base, err := rc.baseFor("entity", handle)
+	if err != nil {
+		return nil, err
+	}
+	return rc.followRDAP(base + "/entity/" + handle)
+}
+
+// QueryHelp performs an RDAP help lookup against an explicit server base
+// URL (RFC 9082 3.1.3); unlike the other query methods, help has no key to
+// bootstrap from, so the server must be supplied directly
+func (rc *RDAPClient) QueryHelp(server string) (*RDAPResponse, error) {
+	return rc.followRDAP(strings.TrimRight(server, "/") + "/help")
+}
+
+// SearchDomains searches for domains matching pattern, e.g. "example*.com"
+// (RFC 9082 3.2.1)
+func (rc *RDAPClient) SearchDomains(pattern string) (*RDAPSearchResponse, error) {
+	base, err := rc.baseFor("domain", pattern)
+	if err != nil {
+		return nil, err
+	}
+	return rc.fetchRDAPSearch(base + "/domains?name=" + url.QueryEscape(pattern))
+}
+
+// SearchDomainsByNameserver searches for domains delegated to a nameserver
+// whose ldhName matches pattern (RFC 9082 3.2.2)
+func (rc *RDAPClient) SearchDomainsByNameserver(pattern string) (*RDAPSearchResponse, error) {
+	base, err := rc.baseFor("domain", pattern)
+	if err != nil {
+		return nil, err
+	}
+	return rc.fetchRDAPSearch(base + "/domains?nsLdhName=" + url.QueryEscape(pattern))
+}
+
+// SearchDomainsByNameserverIP searches for domains delegated to a
+// nameserver with the given IP address (RFC 9082 3.2.2)
+func (rc *RDAPClient) SearchDomainsByNameserverIP(ip string) (*RDAPSearchResponse, error) {
+	base, err := rc.baseFor("ip", ip)
+	if err != nil {
+		return nil, err
+	}
+	return rc.fetchRDAPSearch(base + "/domains?nsIp=" + url.QueryEscape(ip))
+}
+
+// SearchNameservers searches for nameservers whose ldhName matches pattern
+// (RFC 9082 3.2.3)
+func (rc *RDAPClient) SearchNameservers(pattern string) (*RDAPSearchResponse, error) {
+	base, err := rc.baseFor("nameserver", pattern)
+	if err != nil {
+		return nil, err
+	}
+	return rc.fetchRDAPSearch(base + "/nameservers?name=" + url.QueryEscape(pattern))
+}
+
+// SearchNameserversByIP searches for nameservers with the given IP address
+// (RFC 9082 3.2.3)
+func (rc *RDAPClient) SearchNameserversByIP(ip string) (*RDAPSearchResponse, error) {
+	base, err := rc.baseFor("ip", ip)
+	if err != nil {
+		return nil, err
+	}
+	return rc.fetchRDAPSearch(base + "/nameservers?ip=" + url.QueryEscape(ip))
+}
+
+// SearchEntities searches for entities whose fn (full name) matches pattern
+// (RFC 9082 3.2.4)
+func (rc *RDAPClient) SearchEntities(pattern string) (*RDAPSearchResponse, error) {
+	base, err := rc.baseFor("entity", pattern)
+	if err != nil {
+		return nil, err
+	}
+	return rc.fetchRDAPSearch(base + "/entities?fn=" + url.QueryEscape(pattern))
+}
+
+// SearchEntitiesByHandle searches for entities whose handle matches pattern
+// (RFC 9082 3.2.4)
+func (rc *RDAPClient) SearchEntitiesByHandle(pattern string) (*RDAPSearchResponse, error) {
+	base, err := rc.baseFor("entity", pattern)
+	if err != nil {
+		return nil, err
+	}
+	return rc.fetchRDAPSearch(base + "/entities?handle=" + url.QueryEscape(pattern))
+}
+
+// baseFor resolves the RDAP base URL for objectClass/key, honoring
+// rc.Server as an override before falling back to bootstrap
+func (rc *RDAPClient) baseFor(objectClass, key string) (string, error) {
+	if rc.Server != "" {
+		return rc.Server, nil
+	}
+
+	switch objectClass {
+	case "domain", "nameserver":
+		return rc.Bootstrap.DomainServer(key)
+	case "ip":
+		return rc.Bootstrap.IPServer(key)
+	case "autnum":
+		return rc.Bootstrap.ASNServer(key)
+	case "entity":
+		return entityServer(key)
+	default:
+		return "", fmt.Errorf("unsupported RDAP object class %q", objectClass)
+	}
+}
+
+// fetchRDAPSearch issues a single HTTPS GET against a search URL and
+// decodes the RDAP search response JSON body
+func (rc *RDAPClient) fetchRDAPSearch(rawURL string) (*RDAPSearchResponse, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/rdap+json")
+
+	resp, err := rc.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("RDAP search request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("RDAP server returned unexpected status %s for %s", resp.Status, rawURL)
+	}
+
+	response := &RDAPSearchResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, fmt.Errorf("failed to decode RDAP search response: %w", err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * RDAP nameserver/entity/help/search query tests for whois package
+ */
+
+package whois
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntityServer(t *testing.T) {
+	server, err := entityServer("SOME-HANDLE-ARIN")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://rdap.arin.net/registry", server)
+
+	server, err = entityServer("some-handle-ripe")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://rdap.db.ripe.net", server)
+
+	_, err = entityServer("UNKNOWN-HANDLE")
+	assert.NotNil(t, err)
+}
+
+func TestRDAPClientBaseForHonorsServerOverride(t *testing.T) {
+	client := NewRDAPClient()
+	client.Server = "https://rdap.example.test"
+
+	base, err := client.baseFor("entity", "anything")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://rdap.example.test", base)
+}
+
+func TestRDAPClientTypeOverride(t *testing.T) {
+	client := NewRDAPClient()
+	client.Type = "nameserver"
+	client.Server = "https://rdap.example.test"
+
+	base, objectClass, err := client.resolveRDAPEndpoint("15169", "", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "nameserver", objectClass)
+	assert.Equal(t, "https://rdap.example.test", base)
+}
+
+func TestRDAPClientResolveRDAPEndpointPerCallOverride(t *testing.T) {
+	client := NewRDAPClient()
+	client.Type = "domain"
+	client.Server = "https://rdap.example.test"
+
+	base, objectClass, err := client.resolveRDAPEndpoint("15169", "autnum", "https://rdap.override.test")
+	assert.Nil(t, err)
+	assert.Equal(t, "autnum", objectClass)
+	assert.Equal(t, "https://rdap.override.test", base)
+}
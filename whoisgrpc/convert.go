@@ -0,0 +1,174 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Conversion between whois.RDAPResponse and the generated protobuf messages
+ */
+
+package whoisgrpc
+
+import "github.com/ducksify/whois"
+
+// toProtoResponse converts a whois.RDAPResponse into its protobuf twin
+func toProtoResponse(r *whois.RDAPResponse) *RDAPResponse {
+	if r == nil {
+		return nil
+	}
+
+	out := &RDAPResponse{
+		RdapConformance: r.RdapConformance,
+		Handle:          r.Handle,
+		StartAddress:    r.StartAddress,
+		EndAddress:      r.EndAddress,
+		IpVersion:       r.IPVersion,
+		Name:            r.Name,
+		Type:            r.Type,
+		Port43:          r.Port43,
+		Status:          r.Status,
+		ObjectClassName: r.ObjectClassName,
+		LdhName:         r.LdhName,
+		UnicodeName:     r.UnicodeName,
+		Autnum:          r.Autnum,
+	}
+
+	for _, notice := range r.Notices {
+		out.Notices = append(out.Notices, &RDAPNotice{
+			Title:       notice.Title,
+			Type:        notice.Type,
+			Description: notice.Description,
+		})
+	}
+
+	for _, entity := range r.Entities {
+		out.Entities = append(out.Entities, toProtoEntity(&entity))
+	}
+
+	for _, event := range r.Events {
+		out.Events = append(out.Events, &RDAPEvent{
+			EventAction: event.EventAction,
+			EventActor:  event.EventActor,
+			EventDate:   event.EventDate,
+		})
+	}
+
+	for _, link := range r.Links {
+		out.Links = append(out.Links, &RDAPLink{
+			Value: link.Value,
+			Rel:   link.Rel,
+			Href:  link.Href,
+			Type:  link.Type,
+		})
+	}
+
+	for _, ns := range r.Nameservers {
+		protoNS := &RDAPNameserver{
+			ObjectClassName: ns.ObjectClassName,
+			LdhName:         ns.LdhName,
+			UnicodeName:     ns.UnicodeName,
+			Handle:          ns.Handle,
+			Status:          ns.Status,
+		}
+
+		if ns.IPAddresses != nil {
+			protoNS.IpAddresses = &RDAPIPs{V4: ns.IPAddresses.V4, V6: ns.IPAddresses.V6}
+		}
+
+		for _, event := range ns.Events {
+			protoNS.Events = append(protoNS.Events, &RDAPEvent{
+				EventAction: event.EventAction,
+				EventActor:  event.EventActor,
+				EventDate:   event.EventDate,
+			})
+		}
+
+		out.Nameservers = append(out.Nameservers, protoNS)
+	}
+
+	if r.SecureDNS != nil {
+		out.SecureDns = &RDAPSecureDNS{
+			ZoneSigned:       r.SecureDNS.ZoneSigned,
+			DelegationSigned: r.SecureDNS.DelegationSigned,
+			MaxSigLife:       int32(r.SecureDNS.MaxSigLife),
+		}
+		for _, ds := range r.SecureDNS.DSData {
+			out.SecureDns.DsData = append(out.SecureDns.DsData, &RDAPDSData{
+				KeyTag:     int32(ds.KeyTag),
+				Algorithm:  int32(ds.Algorithm),
+				DigestType: int32(ds.DigestType),
+				Digest:     ds.Digest,
+			})
+		}
+	}
+
+	if r.Network != nil {
+		out.Network = &RDAPNetwork{
+			ObjectClassName: r.Network.ObjectClassName,
+			Handle:          r.Network.Handle,
+			StartAddress:    r.Network.StartAddress,
+			EndAddress:      r.Network.EndAddress,
+			IpVersion:       r.Network.IPVersion,
+			Name:            r.Network.Name,
+			Country:         r.Network.Country,
+			ParentHandle:    r.Network.ParentHandle,
+		}
+	}
+
+	for _, remark := range r.Remarks {
+		out.Remarks = append(out.Remarks, &RDAPRemark{
+			Title:       remark.Title,
+			Type:        remark.Type,
+			Description: remark.Description,
+		})
+	}
+
+	return out
+}
+
+// toProtoEntity converts a whois.RDAPEntity into its protobuf twin
+func toProtoEntity(e *whois.RDAPEntity) *RDAPEntity {
+	out := &RDAPEntity{
+		ObjectClassName:  e.ObjectClassName,
+		Handle:           e.Handle,
+		Roles:            e.Roles,
+		FullName:         e.FullName,
+		OrganizationName: e.OrganizationName,
+	}
+
+	for _, email := range e.EmailAddresses {
+		out.EmailAddresses = append(out.EmailAddresses, &RDAPEmailAddress{Type: email.Type, Value: email.Value})
+	}
+
+	for _, phone := range e.PhoneNumbers {
+		out.PhoneNumbers = append(out.PhoneNumbers, &RDAPPhoneNumber{Type: phone.Type, Value: phone.Value})
+	}
+
+	for _, addr := range e.Addresses {
+		out.Addresses = append(out.Addresses, &RDAPAddress{
+			Type:            addr.Type,
+			PostOfficeBox:   addr.PostOfficeBox,
+			ExtendedAddress: addr.ExtendedAddress,
+			StreetAddress:   addr.StreetAddress,
+			Locality:        addr.Locality,
+			Region:          addr.Region,
+			PostalCode:      addr.PostalCode,
+			CountryName:     addr.CountryName,
+		})
+	}
+
+	for _, child := range e.Entities {
+		out.Entities = append(out.Entities, toProtoEntity(&child))
+	}
+
+	return out
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2014-2024 Li Kexian
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * gRPC server exposing whois.RDAPClient as WhoisService
+ */
+
+package whoisgrpc
+
+import (
+	"context"
+
+	"github.com/ducksify/whois"
+	"google.golang.org/grpc"
+)
+
+// server implements the generated WhoisServiceServer interface on top of a
+// whois.RDAPClient
+type server struct {
+	UnimplementedWhoisServiceServer
+
+	client *whois.RDAPClient
+}
+
+// NewServer returns a *grpc.Server that serves WhoisService using client
+// for every lookup, sharing its rate-limiting and cache configuration with
+// the batch query API
+func NewServer(client *whois.RDAPClient, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(opts...)
+	RegisterWhoisServiceServer(s, &server{client: client})
+	return s
+}
+
+// Whois serves a single raw WHOIS lookup
+func (s *server) Whois(ctx context.Context, req *WhoisRequest) (*WhoisReply, error) {
+	text, err := s.client.Whois(req.GetQuery(), serverArg(req.GetServer())...)
+	if err != nil {
+		return nil, err
+	}
+	return &WhoisReply{RawText: text}, nil
+}
+
+// QueryRDAP serves a single structured RDAP lookup
+func (s *server) QueryRDAP(ctx context.Context, req *RDAPRequest) (*RDAPReply, error) {
+	response, err := s.client.QueryRDAPAs(req.GetQuery(), req.GetType(), req.GetServer())
+	if err != nil {
+		return nil, err
+	}
+
+	return &RDAPReply{Response: toProtoResponse(response)}, nil
+}
+
+// QueryRDAPBatch streams RDAP lookups as they complete, backed by
+// RDAPClient.QueryBatch
+func (s *server) QueryRDAPBatch(req *BatchRequest, stream WhoisService_QueryRDAPBatchServer) error {
+	results := s.client.QueryBatch(stream.Context(), req.GetQueries(), whois.BatchOptions{
+		MaxConcurrentPerHost:  int(req.GetMaxConcurrentPerHost()),
+		GlobalConcurrency:     int(req.GetGlobalConcurrency()),
+		RateLimit:             req.GetRateLimit(),
+		RetryOn429WithBackoff: req.GetRetryOn429WithBackoff(),
+	})
+
+	for result := range results {
+		reply := &BatchReply{
+			Query:     result.Query,
+			Server:    result.Server,
+			ElapsedMs: result.Elapsed.Milliseconds(),
+			Response:  toProtoResponse(result.Response),
+		}
+		if result.Err != nil {
+			reply.Error = result.Err.Error()
+		}
+
+		if err := stream.Send(reply); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func serverArg(server string) []string {
+	if server == "" {
+		return nil
+	}
+	return []string{server}
+}